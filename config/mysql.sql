@@ -40,10 +40,16 @@ CREATE TABLE user (
     first_name VARCHAR(50) NOT NULL,
     last_name VARCHAR(50) NOT NULL,
     email VARCHAR(100) NOT NULL,
-    password CHAR(60) NOT NULL,
+    password VARCHAR(255) NOT NULL,
     
     status_id TINYINT(1) UNSIGNED NOT NULL DEFAULT 1,
     
+    last_login_at TIMESTAMP NULL DEFAULT NULL,
+    
+    timezone VARCHAR(64) NOT NULL DEFAULT '',
+    
+    session_epoch INT(10) UNSIGNED NOT NULL DEFAULT 0,
+    
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     deleted TINYINT(1) UNSIGNED NOT NULL DEFAULT 0,
@@ -58,18 +64,275 @@ INSERT INTO `user_status` (`id`, `status`, `created_at`, `updated_at`, `deleted`
 (1, 'active',   CURRENT_TIMESTAMP,  CURRENT_TIMESTAMP,  0),
 (2, 'inactive', CURRENT_TIMESTAMP,  CURRENT_TIMESTAMP,  0);
 
+CREATE TABLE user_role (
+    user_id INT(10) UNSIGNED NOT NULL,
+    
+    role VARCHAR(50) NOT NULL,
+    
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    
+    CONSTRAINT `f_user_role_user` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    
+    PRIMARY KEY (user_id, role)
+);
+
+CREATE TABLE user_permission (
+    user_id INT(10) UNSIGNED NOT NULL,
+    
+    permission VARCHAR(100) NOT NULL,
+    
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    
+    CONSTRAINT `f_user_permission_user` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    
+    PRIMARY KEY (user_id, permission)
+);
+
+CREATE TABLE notebook (
+    id INT(10) UNSIGNED NOT NULL AUTO_INCREMENT,
+    
+    name VARCHAR(64) NOT NULL,
+    
+    parent_id INT(10) UNSIGNED NULL DEFAULT NULL,
+    user_id INT(10) UNSIGNED NOT NULL,
+    
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    
+    CONSTRAINT `f_notebook_parent` FOREIGN KEY (`parent_id`) REFERENCES `notebook` (`id`) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT `f_notebook_user` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    
+    PRIMARY KEY (id)
+);
+
 CREATE TABLE note (
     id INT(10) UNSIGNED NOT NULL AUTO_INCREMENT,
     
-    content TEXT NOT NULL,
+    title VARCHAR(128) NOT NULL DEFAULT '',
+    content MEDIUMTEXT NOT NULL,
+    pinned TINYINT(1) UNSIGNED NOT NULL DEFAULT 0,
+    archived TINYINT(1) UNSIGNED NOT NULL DEFAULT 0,
     
+    notebook_id INT(10) UNSIGNED NULL DEFAULT NULL,
     user_id INT(10) UNSIGNED NOT NULL,
     
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     deleted TINYINT(1) UNSIGNED NOT NULL DEFAULT 0,
     
+    CONSTRAINT `f_note_notebook` FOREIGN KEY (`notebook_id`) REFERENCES `notebook` (`id`) ON DELETE SET NULL ON UPDATE CASCADE,
     CONSTRAINT `f_note_user` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
     
     PRIMARY KEY (id)
-);
\ No newline at end of file
+);
+
+CREATE TABLE note_tag (
+    note_id INT(10) UNSIGNED NOT NULL,
+    user_id INT(10) UNSIGNED NOT NULL,
+    
+    name VARCHAR(32) NOT NULL,
+    
+    CONSTRAINT `f_note_tag_note` FOREIGN KEY (`note_id`) REFERENCES `note` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT `f_note_tag_user` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    
+    KEY (user_id, name),
+    PRIMARY KEY (note_id, name)
+);
+
+CREATE TABLE note_share (
+    id INT(10) UNSIGNED NOT NULL AUTO_INCREMENT,
+    
+    note_id INT(10) UNSIGNED NOT NULL,
+    owner_id INT(10) UNSIGNED NOT NULL,
+    user_id INT(10) UNSIGNED NOT NULL,
+    
+    permission VARCHAR(10) NOT NULL,
+    
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    
+    UNIQUE KEY (note_id, user_id),
+    KEY (user_id),
+    CONSTRAINT `f_note_share_note` FOREIGN KEY (`note_id`) REFERENCES `note` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT `f_note_share_owner` FOREIGN KEY (`owner_id`) REFERENCES `user` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT `f_note_share_user` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE share_link (
+    id INT(10) UNSIGNED NOT NULL AUTO_INCREMENT,
+    
+    token_hash CHAR(64) NOT NULL,
+    prefix VARCHAR(20) NOT NULL,
+    password VARCHAR(255) NOT NULL DEFAULT '',
+    views INT(10) UNSIGNED NOT NULL DEFAULT 0,
+    failures INT(10) UNSIGNED NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP NULL DEFAULT NULL,
+    
+    note_id INT(10) UNSIGNED NOT NULL,
+    user_id INT(10) UNSIGNED NOT NULL,
+    
+    expires_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    
+    UNIQUE KEY (token_hash),
+    CONSTRAINT `f_share_link_note` FOREIGN KEY (`note_id`) REFERENCES `note` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT `f_share_link_user` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE attachment (
+    id INT(10) UNSIGNED NOT NULL AUTO_INCREMENT,
+    
+    name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT UNSIGNED NOT NULL,
+    blob_key VARCHAR(255) NOT NULL,
+    
+    note_id INT(10) UNSIGNED NOT NULL,
+    user_id INT(10) UNSIGNED NOT NULL,
+    
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    
+    CONSTRAINT `f_attachment_note` FOREIGN KEY (`note_id`) REFERENCES `note` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE audit (
+    id INT(10) UNSIGNED NOT NULL AUTO_INCREMENT,
+    
+    actor_id VARCHAR(24) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    target_id VARCHAR(24) NOT NULL,
+    detail VARCHAR(255) NOT NULL,
+    
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE api_token (
+    id INT(10) UNSIGNED NOT NULL AUTO_INCREMENT,
+    
+    name VARCHAR(50) NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    prefix VARCHAR(20) NOT NULL,
+    scope VARCHAR(255) NOT NULL,
+    
+    user_id INT(10) UNSIGNED NOT NULL,
+    
+    last_used_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    
+    UNIQUE KEY (token_hash),
+    CONSTRAINT `f_api_token_user` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE invite (
+    id INT(10) UNSIGNED NOT NULL AUTO_INCREMENT,
+    
+    code_hash CHAR(64) NOT NULL,
+    email VARCHAR(100) NOT NULL,
+    created_by VARCHAR(24) NOT NULL,
+    
+    expires_at DATETIME NOT NULL,
+    used_at DATETIME NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    
+    UNIQUE KEY (code_hash),
+    
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE login_token (
+    id INT(10) UNSIGNED NOT NULL AUTO_INCREMENT,
+    
+    token_hash CHAR(64) NOT NULL,
+    email VARCHAR(100) NOT NULL,
+    
+    expires_at DATETIME NOT NULL,
+    used_at DATETIME NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    
+    UNIQUE KEY (token_hash),
+    KEY (email, created_at),
+    
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE credential (
+    id INT(10) UNSIGNED NOT NULL AUTO_INCREMENT,
+    
+    name VARCHAR(50) NOT NULL,
+    credential_id VARCHAR(255) NOT NULL,
+    public_key BLOB NOT NULL,
+    sign_count INT(10) UNSIGNED NOT NULL DEFAULT 0,
+    
+    user_id INT(10) UNSIGNED NOT NULL,
+    
+    last_used_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    
+    UNIQUE KEY (credential_id),
+    CONSTRAINT `f_credential_user` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE login_event (
+    id INT(10) UNSIGNED NOT NULL AUTO_INCREMENT,
+    
+    user_id INT(10) UNSIGNED NOT NULL,
+    success TINYINT(1) UNSIGNED NOT NULL,
+    ip VARCHAR(45) NOT NULL,
+    user_agent VARCHAR(255) NOT NULL,
+    fingerprint CHAR(64) NOT NULL,
+    
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    
+    KEY (user_id, fingerprint),
+    CONSTRAINT `f_login_event_user` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE remember_token (
+    id INT(10) UNSIGNED NOT NULL AUTO_INCREMENT,
+    
+    selector CHAR(16) NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    prev_hash VARCHAR(64) NOT NULL DEFAULT '',
+    rotated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    user_id INT(10) UNSIGNED NOT NULL,
+    user_agent VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    last_used_at TIMESTAMP NULL DEFAULT NULL,
+    
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    
+    UNIQUE KEY (selector),
+    CONSTRAINT `f_remember_token_user` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE job (
+    id INT(10) UNSIGNED NOT NULL AUTO_INCREMENT,
+    
+    kind VARCHAR(32) NOT NULL,
+    job_key VARCHAR(191) NOT NULL,
+    payload TEXT NOT NULL,
+    run_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    attempts INT(10) UNSIGNED NOT NULL DEFAULT 0,
+    last_error VARCHAR(255) NOT NULL DEFAULT '',
+    
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    
+    UNIQUE KEY (job_key),
+    KEY (run_at),
+    
+    PRIMARY KEY (id)
+);
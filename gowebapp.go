@@ -6,15 +6,23 @@ import (
 	"os"
 	"runtime"
 
+	"app/controller"
+	"app/model"
 	"app/route"
+	"app/shared/blob"
 	"app/shared/database"
 	"app/shared/email"
 	"app/shared/jsonconfig"
+	"app/shared/passhash"
+	"app/shared/passpolicy"
 	"app/shared/recaptcha"
+	"app/shared/registration"
+	"app/shared/scheduler"
 	"app/shared/server"
 	"app/shared/session"
 	"app/shared/view"
 	"app/shared/view/plugin"
+	"app/shared/webauthn"
 )
 
 // *****************************************************************************
@@ -36,10 +44,42 @@ func main() {
 	// Configure the session cookie store
 	session.Configure(config.Session)
 
+	// Refuse to start in production with the sample key from config.json
+	if config.Production && session.UsesSampleKey() {
+		log.Fatalln("The sample session SecretKey must be replaced in production, see Session in config.json")
+	}
+
+	// Configure the password hashing and policy
+	passhash.Configure(config.Passhash)
+	passpolicy.Configure(config.Passpolicy)
+
+	// Configure the relying party for passkeys
+	webauthn.Configure(config.WebAuthn)
+
 	// Connect to database
 	database.Connect(config.Database)
 
-	// Configure the Google reCAPTCHA prior to loading view plugins
+	// Configure where the attached files are stored
+	if err := blob.Configure(config.Blob); err != nil {
+		log.Fatalln(err)
+	}
+
+	// Grant the admin role to the users listed in the config
+	grantAdmins(config.Admins)
+
+	// Configure the email server used for links sent to users
+	email.Configure(config.Email)
+
+	// Run the jobs kept in the database, like note reminders, including the
+	// ones that came due while the server was down
+	scheduler.Configure(config.Scheduler)
+	scheduler.Handle(model.JobReminder, controller.ReminderJob)
+	scheduler.Handle(model.JobNotePurge, model.NotePurgeJob)
+	scheduler.Start(model.JobStore{})
+
+	// Configure the registration mode and the Google reCAPTCHA prior to
+	// loading view plugins
+	registration.Configure(config.Registration)
 	recaptcha.Configure(config.Recaptcha)
 
 	// Setup the views
@@ -49,12 +89,29 @@ func main() {
 		plugin.TagHelper(config.View),
 		plugin.NoEscape(),
 		plugin.PrettyTime(),
-		recaptcha.Plugin())
+		plugin.Markdown(),
+		recaptcha.Plugin(),
+		registration.Plugin())
 
 	// Start the listener
 	server.Run(route.LoadHTTP(), route.LoadHTTPS(), config.Server)
 }
 
+// grantAdmins gives the admin role to each existing user by email
+func grantAdmins(emails []string) {
+	for _, email := range emails {
+		user, err := model.UserByEmail(email)
+		if err != nil {
+			log.Println("Admin not granted to", email, err)
+			continue
+		}
+
+		if err = model.UserRoleAdd(user.UserID(), model.RoleAdmin); err != nil {
+			log.Println("Admin not granted to", email, err)
+		}
+	}
+}
+
 // *****************************************************************************
 // Application Settings
 // *****************************************************************************
@@ -64,13 +121,21 @@ var config = &configuration{}
 
 // configuration contains the application settings
 type configuration struct {
-	Database  database.Info   `json:"Database"`
-	Email     email.SMTPInfo  `json:"Email"`
-	Recaptcha recaptcha.Info  `json:"Recaptcha"`
-	Server    server.Server   `json:"Server"`
-	Session   session.Session `json:"Session"`
-	Template  view.Template   `json:"Template"`
-	View      view.View       `json:"View"`
+	Admins       []string          `json:"Admins"`
+	Blob         blob.Info         `json:"Blob"`
+	Database     database.Info     `json:"Database"`
+	Email        email.SMTPInfo    `json:"Email"`
+	Passhash     passhash.Info     `json:"Passhash"`
+	Passpolicy   passpolicy.Info   `json:"Passpolicy"`
+	Production   bool              `json:"Production"`
+	Recaptcha    recaptcha.Info    `json:"Recaptcha"`
+	Registration registration.Info `json:"Registration"`
+	Scheduler    scheduler.Info    `json:"Scheduler"`
+	Server       server.Server     `json:"Server"`
+	Session      session.Session   `json:"Session"`
+	Template     view.Template     `json:"Template"`
+	View         view.View         `json:"View"`
+	WebAuthn     webauthn.Info     `json:"WebAuthn"`
 }
 
 // ParseJSON unmarshals bytes to structs
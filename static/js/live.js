@@ -0,0 +1,39 @@
+// Keeps the notepad up to date when notes are changed in another browser or by
+// the users they are shared with. The list of notes is loaded again in the
+// background with the same filters and swapped for the new one.
+
+$(function() {
+	var notes = $('#notepad-notes');
+
+	if (!notes.length || !window.EventSource) {
+		return;
+	}
+
+	var timer = null;
+
+	function refresh() {
+		// Wait for the user to leave a field they are typing in
+		if ($.contains(notes[0], document.activeElement) && $(document.activeElement).is('input, textarea, select')) {
+			timer = setTimeout(refresh, 2000);
+			return;
+		}
+
+		$.get(notes.data('notes') + window.location.search).done(function(html) {
+			var fresh = $('<div>').append($.parseHTML(html)).find('#notepad-notes');
+			if (fresh.length) {
+				notes.html(fresh.html());
+			}
+		});
+	}
+
+	// Several changes at once only load the page one time
+	function changed() {
+		clearTimeout(timer);
+		timer = setTimeout(refresh, 500);
+	}
+
+	var source = new EventSource(notes.data('events'));
+	$.each(['note.created', 'note.updated', 'note.deleted'], function(i, type) {
+		source.addEventListener(type, changed);
+	});
+});
@@ -0,0 +1,25 @@
+// Preview tab for the Markdown notes. The server renders and sanitizes the
+// HTML so the preview looks the same as the saved note.
+
+$(function() {
+	$('a[href="#note-preview"]').on('show.bs.tab', function() {
+		var preview = $('#note-preview .markdown');
+		preview.text('Loading preview...');
+
+		$.ajax({
+			url: $('#BaseURI').val() + 'notepad/preview',
+			method: 'POST',
+			contentType: 'application/json',
+			data: JSON.stringify({content: $('#note').val()}),
+			dataType: 'html'
+		}).done(function(html) {
+			if ($.trim(html) === '') {
+				preview.html('<p class="text-muted">Nothing to preview.</p>');
+			} else {
+				preview.html(html);
+			}
+		}).fail(function() {
+			preview.html('<p class="text-danger">The preview could not be loaded.</p>');
+		});
+	});
+});
@@ -0,0 +1,55 @@
+// Autocomplete for the tags field. The user's tags are loaded once from the
+// API and the ones that start with the tag being typed are suggested.
+
+$(function() {
+	var input = $('#tags');
+	var menu = $('#tags-suggestions');
+	var tags = [];
+
+	$.getJSON('/api/v1/tags').done(function(list) {
+		tags = $.map(list, function(t) { return t.name; });
+	});
+
+	// The tags before the one being typed
+	function previous() {
+		var parts = input.val().split(',');
+		parts.pop();
+		return $.map(parts, function(t) { return $.trim(t) || null; });
+	}
+
+	function current() {
+		return $.trim(input.val().split(',').pop()).toLowerCase();
+	}
+
+	function suggest() {
+		var typed = current();
+		var used = previous();
+		menu.empty();
+
+		if (typed === '') {
+			menu.hide();
+			return;
+		}
+
+		$.each(tags, function(i, t) {
+			if (t.indexOf(typed) === 0 && t !== typed && $.inArray(t, used) === -1) {
+				$('<li><a href="#"></a></li>').find('a').text(t).end().appendTo(menu);
+			}
+		});
+		menu.toggle(menu.children().length > 0);
+	}
+
+	input.on('input', suggest);
+	input.on('blur', function() {
+		// Let a click on a suggestion land first
+		setTimeout(function() { menu.hide(); }, 200);
+	});
+
+	menu.on('mousedown', 'a', function(e) {
+		e.preventDefault();
+		var used = previous();
+		used.push($(this).text());
+		input.val(used.join(', ') + ', ').focus();
+		menu.hide();
+	});
+});
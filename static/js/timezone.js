@@ -0,0 +1,9 @@
+// Fills in the timezone of the browser when the user hasn't set one yet.
+
+$(function() {
+	var input = $('#timezone');
+
+	if (input.length && !input.val() && window.Intl) {
+		input.val(Intl.DateTimeFormat().resolvedOptions().timeZone || '');
+	}
+});
@@ -0,0 +1,115 @@
+// Passkey sign in and registration with the WebAuthn API. The server sends
+// and receives the binary values as base64url strings.
+
+function base64urlDecode(s)
+{
+	s = s.replace(/-/g, '+').replace(/_/g, '/');
+	while (s.length % 4) s += '=';
+	var raw = atob(s);
+	var bytes = new Uint8Array(raw.length);
+	for (var i = 0; i < raw.length; i++) bytes[i] = raw.charCodeAt(i);
+	return bytes.buffer;
+}
+
+function base64urlEncode(buf)
+{
+	if (!buf) return '';
+	var bytes = new Uint8Array(buf);
+	var raw = '';
+	for (var i = 0; i < bytes.length; i++) raw += String.fromCharCode(bytes[i]);
+	return btoa(raw).replace(/\+/g, '-').replace(/\//g, '_').replace(/=+$/, '');
+}
+
+function passkeyPost(url, body)
+{
+	return fetch(url, {
+		method: 'POST',
+		credentials: 'same-origin',
+		headers: {'Content-Type': 'application/json'},
+		body: body ? JSON.stringify(body) : ''
+	}).then(function(res) {
+		return res.json().then(function(data) {
+			if (!res.ok) throw new Error(data.error || 'Request failed.');
+			return data;
+		});
+	});
+}
+
+function passkeyShowError(err)
+{
+	var message = err.name === 'NotAllowedError' ? 'Passkey request was cancelled.' : err.message;
+	$('#passkey-error').text(message).show();
+}
+
+function passkeyDescriptors(list)
+{
+	return (list || []).map(function(c) {
+		return {type: c.type, id: base64urlDecode(c.id)};
+	});
+}
+
+function passkeyLogin()
+{
+	var baseURI = $('#BaseURI').val();
+	$('#passkey-error').hide();
+
+	passkeyPost(baseURI + 'webauthn/login/begin').then(function(options) {
+		options.challenge = base64urlDecode(options.challenge);
+		options.allowCredentials = passkeyDescriptors(options.allowCredentials);
+		return navigator.credentials.get({publicKey: options});
+	}).then(function(cred) {
+		return passkeyPost(baseURI + 'webauthn/login/finish', {
+			id: cred.id,
+			response: {
+				clientDataJSON: base64urlEncode(cred.response.clientDataJSON),
+				authenticatorData: base64urlEncode(cred.response.authenticatorData),
+				signature: base64urlEncode(cred.response.signature),
+				userHandle: base64urlEncode(cred.response.userHandle)
+			}
+		});
+	}).then(function(data) {
+		window.location = data.redirect;
+	}).catch(passkeyShowError);
+}
+
+function passkeyRegister(name)
+{
+	var baseURI = $('#BaseURI').val();
+	$('#passkey-error').hide();
+
+	passkeyPost(baseURI + 'webauthn/register/begin').then(function(options) {
+		options.challenge = base64urlDecode(options.challenge);
+		options.user.id = base64urlDecode(options.user.id);
+		options.excludeCredentials = passkeyDescriptors(options.excludeCredentials);
+		return navigator.credentials.create({publicKey: options});
+	}).then(function(cred) {
+		return passkeyPost(baseURI + 'webauthn/register/finish', {
+			name: name,
+			id: cred.id,
+			response: {
+				clientDataJSON: base64urlEncode(cred.response.clientDataJSON),
+				attestationObject: base64urlEncode(cred.response.attestationObject)
+			}
+		});
+	}).then(function(data) {
+		window.location = data.redirect;
+	}).catch(passkeyShowError);
+}
+
+$(function() {
+	// Hide the buttons in browsers without passkey support
+	if (!window.PublicKeyCredential) {
+		$('.passkey').hide();
+		return;
+	}
+
+	$('#passkey-login').click(function(e) {
+		e.preventDefault();
+		passkeyLogin();
+	});
+
+	$('#passkey-register').click(function(e) {
+		e.preventDefault();
+		passkeyRegister($('#passkey_name').val());
+	});
+});